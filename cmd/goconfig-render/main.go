@@ -0,0 +1,63 @@
+// Command goconfig-render renders text/template files against a
+// go-config Config, consul-template style, either once or continuously.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	config "github.com/DoraALin/go-config"
+	"github.com/DoraALin/go-config/render"
+	"github.com/DoraALin/go-config/source/consul"
+)
+
+func main() {
+	var (
+		src     = flag.String("template", "", "path to the template file")
+		dest    = flag.String("dest", "", "destination file the template is rendered to")
+		command = flag.String("command", "", "command to run after a changed render")
+		dryRun  = flag.Bool("dry", false, "render to stdout and exit")
+		once    = flag.Bool("once", false, "render once and exit instead of watching for changes")
+		wait    = flag.Duration("wait", 2*time.Second, "debounce window before re-rendering")
+	)
+	flag.Parse()
+
+	if *src == "" {
+		log.Fatal("-template is required")
+	}
+	if *dest == "" && !*dryRun {
+		log.Fatal("-dest is required unless -dry is set")
+	}
+
+	conf := config.NewConfig(config.WithSource(consul.NewSource()))
+	defer conf.Close()
+
+	opts := []render.Option{
+		render.WithDest(*dest),
+		render.WithDryRun(*dryRun),
+	}
+	if *command != "" {
+		opts = append(opts, render.WithCommand(strings.Fields(*command)...))
+	}
+
+	r, err := render.New(conf, *src, opts...)
+	if err != nil {
+		log.Fatalf("error creating renderer: %v", err)
+	}
+
+	if *dryRun || *once {
+		if _, err := r.Render(os.Stdout); err != nil {
+			log.Fatalf("error rendering: %v", err)
+		}
+		return
+	}
+
+	mgr := render.NewManager(conf, *wait, r)
+	if err := mgr.Run(context.Background()); err != nil {
+		log.Fatalf("error running manager: %v", err)
+	}
+}
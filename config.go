@@ -0,0 +1,69 @@
+// Package config is a pluggable dynamic config library that merges
+// config from multiple sources (file, consul, redis, ...) and lets
+// callers watch individual paths for changes.
+package config
+
+import (
+	"context"
+
+	"github.com/DoraALin/go-config/crypto"
+	"github.com/DoraALin/go-config/reader"
+	"github.com/DoraALin/go-config/source"
+)
+
+// Config is the top-level interface for accessing and watching merged
+// configuration from one or more sources.
+type Config interface {
+	// Bytes returns the config as raw bytes, in the Reader's encoding.
+	Bytes() []byte
+	// Get a value at the path, loading the sources on first use.
+	Get(path ...string) Value
+	// GetContext is like Get but bounds the initial sync on ctx.
+	GetContext(ctx context.Context, path ...string) Value
+	// Load adds sources to the config. Their values are merged after
+	// those of existing sources of equal or lower priority, so they do
+	// not override a higher priority source (see WithSourcePriority).
+	Load(source ...source.Source) error
+	// LoadContext is like Load but bounds the initial read on ctx.
+	LoadContext(ctx context.Context, source ...source.Source) error
+	// Watch a path for changes.
+	Watch(path ...string) (Watcher, error)
+	// WatchContext is like Watch but the watcher also stops when ctx
+	// is done.
+	WatchContext(ctx context.Context, path ...string) (Watcher, error)
+	// Close stops all watchers and cancels the config's root context.
+	Close() error
+}
+
+// Watcher watches a config path for changes.
+type Watcher interface {
+	Next() (Value, error)
+	Stop() error
+}
+
+// Options configure a Config.
+type Options struct {
+	Reader  reader.Reader
+	Source  []source.Source
+	Context context.Context
+
+	// MergeStrategy controls how multiple sources resolve conflicting
+	// leaf values when merged; see MergeStrategy.
+	MergeStrategy MergeStrategy
+	// ArrayMergeStrategy controls how DeepMerge/FailOnConflict combine
+	// array leaves; see ArrayMergeStrategy.
+	ArrayMergeStrategy ArrayMergeStrategy
+
+	// Decrypter transparently decrypts leaf values encoded as an
+	// EncryptedValue marker before they're returned from a Value; see
+	// WithDecrypter.
+	Decrypter crypto.Decrypter
+}
+
+// Option sets a Config option.
+type Option func(*Options)
+
+// NewConfig creates a new Config with the given options.
+func NewConfig(opts ...Option) Config {
+	return newConfig(opts...)
+}
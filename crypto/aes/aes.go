@@ -0,0 +1,85 @@
+// Package aes implements crypto.Decrypter using AES-GCM with a local
+// key or keyring, for deployments that don't run a KMS or Vault.
+package aes
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// ErrKeyNotFound is returned when ciphertext names a key id that isn't
+// in the keyring.
+var ErrKeyNotFound = errors.New("aes: key not found")
+
+// Decrypter decrypts AES-GCM ciphertext using a keyring, so keys can be
+// rotated by adding a new id without invalidating values encrypted under
+// an older one.
+type Decrypter struct {
+	keyring map[string][]byte
+}
+
+// NewDecrypter creates a Decrypter backed by a single unnamed key. Use
+// NewKeyringDecrypter for multiple keys addressed by id.
+func NewDecrypter(key []byte) (*Decrypter, error) {
+	return NewKeyringDecrypter(map[string][]byte{"": key})
+}
+
+// NewKeyringDecrypter creates a Decrypter backed by a keyring of raw
+// AES-128/192/256 keys indexed by id. Ciphertext produced alongside this
+// decrypter is expected in the form "<id>:<base64 nonce+sealed>"; id may
+// be empty when there's only ever one key.
+func NewKeyringDecrypter(keyring map[string][]byte) (*Decrypter, error) {
+	for id, key := range keyring {
+		if _, err := aes.NewCipher(key); err != nil {
+			return nil, fmt.Errorf("aes: invalid key %q: %v", id, err)
+		}
+	}
+	return &Decrypter{keyring: keyring}, nil
+}
+
+// Decrypt implements crypto.Decrypter.
+func (d *Decrypter) Decrypt(ciphertext []byte) ([]byte, error) {
+	id, enc := splitKeyID(ciphertext)
+
+	key, ok := d.keyring[id]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(string(enc))
+	if err != nil {
+		return nil, fmt.Errorf("aes: decoding ciphertext: %v", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("aes: ciphertext too short")
+	}
+	nonce, sealed := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+
+	return gcm.Open(nil, nonce, sealed, nil)
+}
+
+// splitKeyID splits "<id>:<base64>" ciphertext into its key id and the
+// remaining base64 payload. With no ":" the whole input is treated as
+// the payload for the unnamed ("") key.
+func splitKeyID(ciphertext []byte) (id string, payload []byte) {
+	for i, b := range ciphertext {
+		if b == ':' {
+			return string(ciphertext[:i]), ciphertext[i+1:]
+		}
+	}
+	return "", ciphertext
+}
@@ -0,0 +1,46 @@
+// Package crypto decrypts leaf config values that were encrypted at
+// rest. A value is treated as encrypted if it matches the EncryptedValue
+// marker format; the reader pipeline strips the marker and hands the
+// remaining ciphertext to a Decrypter before returning it from Value.
+package crypto
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Prefix marks a scalar leaf value as ciphertext, e.g. "enc:<base64>".
+const Prefix = "enc:"
+
+// Decrypter turns ciphertext produced by an EncryptedValue marker back
+// into plaintext. Implementations are free to interpret ciphertext
+// however they need to (e.g. it may embed a key id), as long as Decrypt
+// is deterministic for the same input.
+type Decrypter interface {
+	// Decrypt returns the plaintext for ciphertext, or an error if it
+	// cannot be decrypted.
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// encryptedValue is the structured marker form of an encrypted leaf,
+// e.g. `{"$encrypted":"<base64>"}`, used where a source can't represent
+// a bare "enc:" prefixed string (numeric or boolean leaves).
+type encryptedValue struct {
+	Encrypted string `json:"$encrypted"`
+}
+
+// Ciphertext reports whether value is an EncryptedValue marker and, if
+// so, returns the raw ciphertext it wraps. It recognises both the
+// "enc:<ciphertext>" string form and the `{"$encrypted":"..."}` object
+// form.
+func Ciphertext(value []byte) ([]byte, bool) {
+	if bytes.HasPrefix(value, []byte(Prefix)) {
+		return value[len(Prefix):], true
+	}
+
+	var ev encryptedValue
+	if err := json.Unmarshal(value, &ev); err != nil || ev.Encrypted == "" {
+		return nil, false
+	}
+	return []byte(ev.Encrypted), true
+}
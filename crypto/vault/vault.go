@@ -0,0 +1,128 @@
+// Package vault implements crypto.Decrypter against a HashiCorp Vault
+// transit backend, so config values can be encrypted/rotated under a
+// named transit key without the application ever holding key material.
+package vault
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// Options configure a Decrypter.
+type Options struct {
+	// Address is the Vault server address, e.g. "https://vault:8200".
+	Address string
+	// Token authenticates requests to Vault.
+	Token string
+	// Mount is the transit secrets engine mount point; defaults to
+	// "transit".
+	Mount string
+}
+
+// Option sets a Decrypter option.
+type Option func(*Options)
+
+// WithAddress sets the Vault server address.
+func WithAddress(addr string) Option {
+	return func(o *Options) {
+		o.Address = addr
+	}
+}
+
+// WithToken sets the Vault token used to authenticate requests.
+func WithToken(token string) Option {
+	return func(o *Options) {
+		o.Token = token
+	}
+}
+
+// WithMount sets the transit secrets engine mount point, in place of the
+// default "transit".
+func WithMount(mount string) Option {
+	return func(o *Options) {
+		o.Mount = mount
+	}
+}
+
+// Decrypter decrypts ciphertext via Vault's transit/decrypt/<key>
+// endpoint. The transit key name is read from the ciphertext itself (see
+// Decrypt), so a single Decrypter can serve values encrypted under any
+// number of transit keys, including a key rotated to a new version.
+type Decrypter struct {
+	client *api.Client
+	mount  string
+}
+
+// NewDecrypter creates a Decrypter talking to the Vault server and
+// transit mount described by opts.
+func NewDecrypter(opts ...Option) (*Decrypter, error) {
+	options := Options{
+		Mount: "transit",
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+
+	config := api.DefaultConfig()
+	if options.Address != "" {
+		config.Address = options.Address
+	}
+
+	client, err := api.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("vault: creating client: %v", err)
+	}
+	if options.Token != "" {
+		client.SetToken(options.Token)
+	}
+
+	return &Decrypter{client: client, mount: options.Mount}, nil
+}
+
+// Decrypt implements crypto.Decrypter. ciphertext is expected in the
+// form "<key>:<vault ciphertext>", where <key> names the transit key the
+// value was encrypted under (vault ciphertext already starts with its
+// own "vault:v<n>:" version prefix, so this just records which key to
+// call transit/decrypt/<key> with). Decrypting against the live key
+// means a rotated key works immediately, without re-reading the value.
+func (d *Decrypter) Decrypt(ciphertext []byte) ([]byte, error) {
+	key, vaultCiphertext := splitKeyName(ciphertext)
+	if key == "" {
+		return nil, fmt.Errorf("vault: ciphertext missing \"<key>:\" prefix")
+	}
+
+	secret, err := d.client.Logical().Write(path.Join(d.mount, "decrypt", key), map[string]interface{}{
+		"ciphertext": vaultCiphertext,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("vault: decrypting with key %q: %v", key, err)
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("vault: no response decrypting with key %q", key)
+	}
+
+	plaintext, ok := secret.Data["plaintext"].(string)
+	if !ok {
+		return nil, fmt.Errorf("vault: response missing plaintext for key %q", key)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("vault: decoding plaintext for key %q: %v", key, err)
+	}
+	return decoded, nil
+}
+
+// splitKeyName splits "<key>:<vault ciphertext>" into the transit key
+// name and the remaining vault-formatted ciphertext.
+func splitKeyName(ciphertext []byte) (key string, vaultCiphertext string) {
+	for i, b := range ciphertext {
+		if b == ':' {
+			return string(ciphertext[:i]), string(ciphertext[i+1:])
+		}
+	}
+	return "", string(ciphertext)
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"log"
 	"sync"
@@ -13,7 +14,9 @@ import (
 )
 
 type config struct {
-	exit chan bool
+	ctx    context.Context
+	cancel context.CancelFunc
+
 	opts Options
 
 	sync.RWMutex
@@ -31,7 +34,9 @@ type config struct {
 }
 
 type watcher struct {
-	exit    chan bool
+	ctx    context.Context
+	cancel context.CancelFunc
+
 	path    []string
 	value   Value
 	updates chan Value
@@ -46,20 +51,23 @@ func newConfig(opts ...Option) Config {
 		o(&options)
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	c := &config{
-		exit:     make(chan bool),
+		ctx:      ctx,
+		cancel:   cancel,
 		opts:     options,
 		watchers: make(map[int]*watcher),
 		sources:  options.Source,
 	}
 
 	for i, s := range options.Source {
-		go c.watch(i, s)
+		go c.watch(ctx, i, s)
 	}
 	return c
 }
 
-func (c *config) watch(idx int, s source.Source) {
+func (c *config) watch(ctx context.Context, idx int, s source.Source) {
 	c.Lock()
 	c.sets = append(c.sets, nil)
 	c.Unlock()
@@ -78,9 +86,10 @@ func (c *config) watch(idx int, s source.Source) {
 			// save
 			c.sets[idx] = cs
 
-			// merge sets
-			set, err := c.opts.Reader.Parse(c.sets...)
+			// merge sets, highest priority source last
+			set, err := c.parse(orderSets(c.sources, c.sets))
 			if err != nil {
+				c.Unlock()
 				return err
 			}
 
@@ -96,21 +105,21 @@ func (c *config) watch(idx int, s source.Source) {
 	}
 
 	for {
+		// derive a child context so a single watch attempt can be
+		// cancelled independently of the parent source loop
+		wctx, wcancel := context.WithCancel(ctx)
+
 		// watch the source
-		w, err := s.Watch()
+		w, err := s.Watch(wctx)
 		if err != nil {
+			wcancel()
 			time.Sleep(time.Second)
 			continue
 		}
 
-		done := make(chan bool)
-
 		// the stop watch func
 		go func() {
-			select {
-			case <-done:
-			case <-c.exit:
-			}
+			<-wctx.Done()
 			w.Stop()
 		}()
 
@@ -120,12 +129,12 @@ func (c *config) watch(idx int, s source.Source) {
 			time.Sleep(time.Second)
 		}
 
-		// close done chan
-		close(done)
+		// done with this watch attempt
+		wcancel()
 
 		// if the config is closed exit
 		select {
-		case <-c.exit:
+		case <-ctx.Done():
 			return
 		default:
 		}
@@ -159,24 +168,53 @@ func (c *config) update() {
 	}
 }
 
+// readContext runs src.Read() on a goroutine and returns as soon as
+// either it completes or ctx is done, so a source whose Read call blocks
+// (e.g. a slow or unreachable consul agent) cannot hang the caller past
+// ctx's deadline. The Read call itself has no way to be cancelled and so
+// keeps running in the background, but its result is discarded once ctx
+// wins the race.
+func readContext(ctx context.Context, src source.Source) (*source.ChangeSet, error) {
+	type result struct {
+		set *source.ChangeSet
+		err error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		set, err := src.Read()
+		done <- result{set, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-done:
+		return r.set, r.err
+	}
+}
+
 // sync loads all the sources, calls the parser and updates the config
-func (c *config) sync() {
+func (c *config) sync(ctx context.Context) {
 	var sets []*source.ChangeSet
+	var srcs []source.Source
 
 	c.Lock()
 
 	// read the source
-	for _, source := range c.sources {
-		ch, err := source.Read()
+	for _, src := range c.sources {
+		ch, err := readContext(ctx, src)
 		if err != nil {
 			continue
 		}
 		sets = append(sets, ch)
+		srcs = append(srcs, src)
 	}
 
-	// merge sets
-	set, err := c.opts.Reader.Parse(sets...)
+	// merge sets, highest priority source last
+	set, err := c.parse(orderSets(srcs, sets))
 	if err != nil {
+		c.Unlock()
 		return
 	}
 
@@ -194,8 +232,8 @@ func (c *config) sync() {
 func (c *config) reload() {
 	c.Lock()
 
-	// merge sets
-	set, err := c.opts.Reader.Parse(c.sets...)
+	// merge sets, highest priority source last
+	set, err := c.parse(orderSets(c.sources, c.sets))
 	if err != nil {
 		c.Unlock()
 		return
@@ -213,17 +251,23 @@ func (c *config) reload() {
 
 func (c *config) Close() error {
 	select {
-	case <-c.exit:
+	case <-c.ctx.Done():
 		return nil
 	default:
-		close(c.exit)
+		c.cancel()
 	}
 	return nil
 }
 
 func (c *config) Get(path ...string) Value {
+	return c.GetContext(context.Background(), path...)
+}
+
+// GetContext is like Get but bounds the initial sync on ctx, so a blocked
+// or slow source (e.g. consul) cannot hang the caller forever.
+func (c *config) GetContext(ctx context.Context, path ...string) Value {
 	if !c.loaded() {
-		c.sync()
+		c.sync(ctx)
 	}
 
 	c.Lock()
@@ -266,7 +310,7 @@ func (c *config) Get(path ...string) Value {
 
 func (c *config) Bytes() []byte {
 	if !c.loaded() {
-		c.sync()
+		c.sync(context.Background())
 	}
 
 	c.Lock()
@@ -280,14 +324,19 @@ func (c *config) Bytes() []byte {
 }
 
 func (c *config) Load(sources ...source.Source) error {
-	for _, source := range sources {
-		set, _ := source.Read()
+	return c.LoadContext(context.Background(), sources...)
+}
+
+// LoadContext is like Load but the per-source Read call is bounded by ctx.
+func (c *config) LoadContext(ctx context.Context, sources ...source.Source) error {
+	for _, src := range sources {
+		set, _ := readContext(ctx, src)
 		c.Lock()
-		c.sources = append(c.sources, source)
+		c.sources = append(c.sources, src)
 		c.sets = append(c.sets, set)
 		idx := len(c.sets) - 1
 		c.Unlock()
-		go c.watch(idx, source)
+		go c.watch(c.ctx, idx, src)
 	}
 
 	c.reload()
@@ -295,12 +344,21 @@ func (c *config) Load(sources ...source.Source) error {
 }
 
 func (c *config) Watch(path ...string) (Watcher, error) {
-	value := c.Get(path...)
+	return c.WatchContext(context.Background(), path...)
+}
+
+// WatchContext is like Watch but the watcher is cancelled when ctx is done,
+// in addition to when Stop is called or the config is closed.
+func (c *config) WatchContext(ctx context.Context, path ...string) (Watcher, error) {
+	value := c.GetContext(ctx, path...)
+
+	wctx, wcancel := context.WithCancel(c.ctx)
 
 	c.Lock()
 
 	w := &watcher{
-		exit:    make(chan bool),
+		ctx:     wctx,
+		cancel:  wcancel,
 		path:    path,
 		value:   value,
 		updates: make(chan Value, 1),
@@ -313,7 +371,11 @@ func (c *config) Watch(path ...string) (Watcher, error) {
 	c.Unlock()
 
 	go func() {
-		<-w.exit
+		select {
+		case <-ctx.Done():
+			w.cancel()
+		case <-wctx.Done():
+		}
 		c.Lock()
 		delete(c.watchers, id)
 		c.Unlock()
@@ -325,7 +387,7 @@ func (c *config) Watch(path ...string) (Watcher, error) {
 func (w *watcher) Next() (Value, error) {
 	for {
 		select {
-		case <-w.exit:
+		case <-w.ctx.Done():
 			return nil, errors.New("watcher stopped")
 		case v := <-w.updates:
 			if bytes.Equal(w.value.Bytes(), v.Bytes()) {
@@ -338,10 +400,6 @@ func (w *watcher) Next() (Value, error) {
 }
 
 func (w *watcher) Stop() error {
-	select {
-	case <-w.exit:
-	default:
-		close(w.exit)
-	}
+	w.cancel()
 	return nil
 }
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/DoraALin/go-config/crypto"
+	"github.com/DoraALin/go-config/source"
+)
+
+// WithDecrypter sets the Decrypter used to transparently decrypt leaf
+// values encoded as an EncryptedValue marker (see the crypto package)
+// before they're returned from a Value.
+func WithDecrypter(d crypto.Decrypter) Option {
+	return func(o *Options) {
+		o.Decrypter = d
+	}
+}
+
+// decryptSets decrypts every set in sets, so callers that merge or
+// compare sets (see (c *config) parse in merge.go) always work with
+// plaintext, never raw ciphertext. d == nil is a no-op that returns sets
+// itself; otherwise it returns a new slice and the elements of sets are
+// left untouched.
+func decryptSets(sets []*source.ChangeSet, d crypto.Decrypter) ([]*source.ChangeSet, error) {
+	if d == nil {
+		return sets, nil
+	}
+
+	out := make([]*source.ChangeSet, len(sets))
+	for i, set := range sets {
+		ds, err := decryptChangeSet(set, d)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = ds
+	}
+	return out, nil
+}
+
+// decryptChangeSet returns a copy of set with every EncryptedValue leaf
+// in its (JSON) Data replaced by its decrypted plaintext. set is
+// returned unchanged if d is nil or Data isn't JSON this can walk.
+func decryptChangeSet(set *source.ChangeSet, d crypto.Decrypter) (*source.ChangeSet, error) {
+	if set == nil || d == nil || len(set.Data) == 0 {
+		return set, nil
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(set.Data, &data); err != nil {
+		return set, nil
+	}
+
+	decrypted, err := decryptLeaves(data, d)
+	if err != nil {
+		return nil, fmt.Errorf("config: decrypting %s: %v", set.Source, err)
+	}
+
+	b, err := json.Marshal(decrypted)
+	if err != nil {
+		return nil, fmt.Errorf("config: encoding decrypted %s: %v", set.Source, err)
+	}
+
+	out := *set
+	out.Data = b
+	return &out, nil
+}
+
+// decryptLeaves walks v (the result of unmarshalling a ChangeSet's JSON
+// Data) and decrypts every leaf that matches the EncryptedValue marker
+// format: a bare "enc:<ciphertext>" string, or a {"$encrypted":"..."}
+// object standing in for a leaf that can't be a string in its source
+// (numeric or boolean config values).
+func decryptLeaves(v interface{}, d crypto.Decrypter) (interface{}, error) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		// re-marshal to recognize the {"$encrypted":"..."} marker form
+		// via the same crypto.Ciphertext the "enc:" string form uses.
+		// This form exists precisely for leaves that aren't strings in
+		// their source (numbers, bools, nested objects), so, unlike the
+		// bare "enc:" form, its plaintext is reinterpreted as JSON.
+		if b, err := json.Marshal(val); err == nil {
+			if ciphertext, ok := crypto.Ciphertext(b); ok {
+				return decryptMarker(ciphertext, d, true)
+			}
+		}
+
+		out := make(map[string]interface{}, len(val))
+		for k, child := range val {
+			dv, err := decryptLeaves(child, d)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = dv
+		}
+		return out, nil
+
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, child := range val {
+			dv, err := decryptLeaves(child, d)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = dv
+		}
+		return out, nil
+
+	case string:
+		// the bare "enc:" form is only ever used for string leaves, so
+		// its plaintext is always returned as-is, even if it happens to
+		// look like JSON (e.g. a password of "true" or "12345").
+		if ciphertext, ok := crypto.Ciphertext([]byte(val)); ok {
+			return decryptMarker(ciphertext, d, false)
+		}
+		return val, nil
+
+	default:
+		return val, nil
+	}
+}
+
+// decryptMarker decrypts ciphertext. When reinterpret is true (the
+// {"$encrypted":...} object marker) and the plaintext happens to be
+// valid JSON, the decoded value is returned rather than a string, so a
+// non-string leaf's type survives the round trip; when false (the bare
+// "enc:" string marker) the plaintext is always returned as a string,
+// since that form is only ever used to encrypt strings.
+func decryptMarker(ciphertext []byte, d crypto.Decrypter, reinterpret bool) (interface{}, error) {
+	plaintext, err := d.Decrypt(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	if reinterpret {
+		var v interface{}
+		if err := json.Unmarshal(plaintext, &v); err == nil {
+			return v, nil
+		}
+	}
+	return string(plaintext), nil
+}
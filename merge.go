@@ -0,0 +1,180 @@
+package config
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/DoraALin/go-config/source"
+)
+
+// MergeStrategy controls how conflicting leaf values from different
+// sources are resolved when their change sets are merged.
+type MergeStrategy int
+
+const (
+	// Replace keeps the current behavior: a higher priority (or later
+	// registered) source's leaf value replaces a lower priority one
+	// outright, including whole sub-trees. This is the zero value, and
+	// is handled by the configured Reader's own Parse, same as before
+	// MergeStrategy existed.
+	Replace MergeStrategy = iota
+	// DeepMerge recursively merges maps from every source instead of
+	// replacing whole sub-trees; ArrayMergeStrategy controls whether
+	// arrays are replaced or appended. A later (higher priority) source
+	// still wins a conflicting leaf, same as Replace.
+	DeepMerge
+	// FailOnConflict behaves like DeepMerge except merging returns an
+	// error if two sources set the same leaf to different values.
+	FailOnConflict
+)
+
+// ArrayMergeStrategy controls how DeepMerge/FailOnConflict combine array
+// leaves set by more than one source.
+type ArrayMergeStrategy int
+
+const (
+	// ReplaceArrays keeps the higher priority source's array outright.
+	ReplaceArrays ArrayMergeStrategy = iota
+	// AppendArrays concatenates the lower priority source's array with
+	// the higher priority one.
+	AppendArrays
+)
+
+// WithMergeStrategy sets how sources that set the same leaf to different
+// values are resolved when merged, in place of the default Replace.
+func WithMergeStrategy(m MergeStrategy) Option {
+	return func(o *Options) {
+		o.MergeStrategy = m
+	}
+}
+
+// WithArrayMergeStrategy sets how DeepMerge/FailOnConflict combine array
+// leaves, in place of the default ReplaceArrays. It has no effect under
+// the Replace strategy.
+func WithArrayMergeStrategy(a ArrayMergeStrategy) Option {
+	return func(o *Options) {
+		o.ArrayMergeStrategy = a
+	}
+}
+
+// parse merges sets according to c.opts.MergeStrategy. Replace is left to
+// the configured Reader, same as before MergeStrategy existed. DeepMerge
+// and FailOnConflict are implemented locally, since neither is something
+// the upstream Reader knows how to do: sets are decoded as JSON, merged
+// leaf by leaf (later entries in sets win, so callers should pass sets in
+// priority order, e.g. via orderSets), and re-encoded into a synthetic
+// ChangeSet that's then handed to Reader.Values like any other.
+//
+// Sets are decrypted before merging, not after: AES-GCM and Vault transit
+// ciphertext are non-deterministic, so two sources encrypting the same
+// plaintext produce different ciphertext strings, and comparing that raw
+// ciphertext under FailOnConflict would spuriously reject values that
+// agree once decrypted.
+func (c *config) parse(sets []*source.ChangeSet) (*source.ChangeSet, error) {
+	sets, err := decryptSets(sets, c.opts.Decrypter)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.opts.MergeStrategy == Replace {
+		return c.opts.Reader.Parse(sets...)
+	}
+
+	var merged map[string]interface{}
+	var latest source.ChangeSet
+
+	for _, set := range sets {
+		if set == nil {
+			continue
+		}
+		if set.Timestamp.After(latest.Timestamp) {
+			latest = *set
+		}
+
+		var data map[string]interface{}
+		if len(set.Data) > 0 {
+			if err := json.Unmarshal(set.Data, &data); err != nil {
+				return nil, fmt.Errorf("config: parsing %s: %v", set.Source, err)
+			}
+		}
+
+		if merged == nil {
+			merged = data
+			continue
+		}
+
+		var err error
+		merged, err = mergeMaps(merged, data, c.opts.MergeStrategy, c.opts.ArrayMergeStrategy, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("config: encoding merged set: %v", err)
+	}
+
+	h := md5.New()
+	h.Write(b)
+
+	return &source.ChangeSet{
+		Data:      b,
+		Checksum:  fmt.Sprintf("%x", h.Sum(nil)),
+		Timestamp: latest.Timestamp,
+		Source:    "config",
+	}, nil
+}
+
+// mergeMaps recursively merges src into dst, with src's leaves winning
+// conflicts under DeepMerge, and FailOnConflict returning an error
+// instead for any leaf (non-map, non-mergeable-array) that dst and src
+// disagree on. path tracks the key path for error messages.
+func mergeMaps(dst, src map[string]interface{}, strategy MergeStrategy, arrays ArrayMergeStrategy, path []string) (map[string]interface{}, error) {
+	for k, srcVal := range src {
+		dstVal, ok := dst[k]
+		if !ok {
+			dst[k] = srcVal
+			continue
+		}
+
+		dstMap, dstIsMap := dstVal.(map[string]interface{})
+		srcMap, srcIsMap := srcVal.(map[string]interface{})
+		if dstIsMap && srcIsMap {
+			childPath := append(append([]string{}, path...), k)
+			merged, err := mergeMaps(dstMap, srcMap, strategy, arrays, childPath)
+			if err != nil {
+				return nil, err
+			}
+			dst[k] = merged
+			continue
+		}
+
+		dstArr, dstIsArr := dstVal.([]interface{})
+		srcArr, srcIsArr := srcVal.([]interface{})
+		if dstIsArr && srcIsArr && arrays == AppendArrays {
+			dst[k] = append(append([]interface{}{}, dstArr...), srcArr...)
+			continue
+		}
+
+		if strategy == FailOnConflict && !reflect.DeepEqual(dstVal, srcVal) {
+			return nil, fmt.Errorf("config: conflicting value for %q", joinPath(append(append([]string{}, path...), k)))
+		}
+
+		dst[k] = srcVal
+	}
+	return dst, nil
+}
+
+func joinPath(path []string) string {
+	out := ""
+	for i, p := range path {
+		if i > 0 {
+			out += "/"
+		}
+		out += p
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"sort"
+
+	"github.com/DoraALin/go-config/source"
+)
+
+// prioritySource wraps a source.Source with an explicit merge priority,
+// set via WithSourcePriority. Higher priority sources override lower
+// priority ones regardless of registration or watch-arrival order.
+type prioritySource struct {
+	source.Source
+	priority int
+}
+
+// Priority returns the source's merge priority.
+func (p *prioritySource) Priority() int {
+	return p.priority
+}
+
+// prioritized is implemented by sources that carry an explicit merge
+// priority; sources that don't implement it default to priority 0.
+type prioritized interface {
+	Priority() int
+}
+
+// WithSourcePriority appends a source to the list of sources, like
+// WithSource, but tags it with an explicit priority. When sets are
+// merged, higher priority sources are applied last and so override
+// lower priority (or unprioritized, which default to 0) sources.
+func WithSourcePriority(s source.Source, priority int) Option {
+	return func(o *Options) {
+		o.Source = append(o.Source, &prioritySource{Source: s, priority: priority})
+	}
+}
+
+// sourcePriority returns s's merge priority, or 0 if it wasn't set via
+// WithSourcePriority.
+func sourcePriority(s source.Source) int {
+	if p, ok := s.(prioritized); ok {
+		return p.Priority()
+	}
+	return 0
+}
+
+// orderSets returns sets reordered so that the change set belonging to
+// the highest priority source comes last, ready to hand to Reader.Parse.
+// sets[i] must correspond to sources[i]; sets with no matching source
+// (sets longer than sources) default to priority 0. Equal priority sets
+// keep their relative order, so unprioritized sources merge in
+// registration order exactly as before WithSourcePriority existed.
+func orderSets(sources []source.Source, sets []*source.ChangeSet) []*source.ChangeSet {
+	priority := make([]int, len(sets))
+	for i := range sets {
+		if i < len(sources) {
+			priority[i] = sourcePriority(sources[i])
+		}
+	}
+
+	idx := make([]int, len(sets))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return priority[idx[i]] < priority[idx[j]]
+	})
+
+	ordered := make([]*source.ChangeSet, len(sets))
+	for i, j := range idx {
+		ordered[i] = sets[j]
+	}
+	return ordered
+}
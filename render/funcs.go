@@ -0,0 +1,57 @@
+package render
+
+import (
+	"text/template"
+
+	config "github.com/DoraALin/go-config"
+)
+
+// funcMap builds the template function library that lets templates
+// reference config paths, e.g. {{ key "database/address" }}.
+func funcMap(conf config.Config) template.FuncMap {
+	return template.FuncMap{
+		"key":          keyFunc(conf),
+		"keyOrDefault": keyOrDefaultFunc(conf),
+		"tree":         treeFunc(conf),
+	}
+}
+
+// keyFunc returns the string value at path, split on "/".
+func keyFunc(conf config.Config) func(string) string {
+	return func(path string) string {
+		return conf.Get(splitPath(path)...).String("")
+	}
+}
+
+// keyOrDefaultFunc returns the string value at path, split on "/", or def
+// if the path does not resolve to a value.
+func keyOrDefaultFunc(conf config.Config) func(string, string) string {
+	return func(path, def string) string {
+		return conf.Get(splitPath(path)...).String(def)
+	}
+}
+
+// treeFunc returns the raw bytes under path as a nested map, for ranging
+// over a whole subtree in a template.
+func treeFunc(conf config.Config) func(string) map[string]interface{} {
+	return func(path string) map[string]interface{} {
+		var out map[string]interface{}
+		conf.Get(splitPath(path)...).Scan(&out)
+		return out
+	}
+}
+
+// splitPath turns a "/"-separated config path into the []string form
+// Config.Get expects.
+func splitPath(path string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' {
+			parts = append(parts, path[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, path[start:])
+	return parts
+}
@@ -0,0 +1,110 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	config "github.com/DoraALin/go-config"
+)
+
+// Manager runs one or more Renderers, re-rendering whenever the
+// underlying Config changes. Bursts of updates within the wait window
+// given to NewManager are coalesced into a single re-render.
+type Manager struct {
+	conf      config.Config
+	renderers []*Renderer
+	wait      time.Duration
+}
+
+// NewManager creates a Manager that re-renders every renderer in rs
+// whenever conf changes, debounced by wait.
+func NewManager(conf config.Config, wait time.Duration, rs ...*Renderer) *Manager {
+	return &Manager{
+		conf:      conf,
+		renderers: rs,
+		wait:      wait,
+	}
+}
+
+// Run watches conf for changes and re-renders until ctx is cancelled. The
+// first render happens immediately, before any change is observed.
+func (m *Manager) Run(ctx context.Context) error {
+	if err := m.renderAll(); err != nil {
+		return err
+	}
+
+	w, err := m.conf.WatchContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error watching config: %v", err)
+	}
+	defer w.Stop()
+
+	changes := make(chan struct{}, 1)
+
+	go func() {
+		defer close(changes)
+		for {
+			if _, err := w.Next(); err != nil {
+				return
+			}
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	var timer *time.Timer
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-changes:
+			if !ok {
+				return nil
+			}
+			if timer == nil {
+				timer = time.NewTimer(m.wait)
+			} else {
+				// Reset is only safe on a stopped/expired timer whose
+				// channel has been drained; otherwise a tick already
+				// sitting in timer.C fires immediately on the next
+				// iteration instead of waiting out the fresh m.wait,
+				// collapsing the debounce on a race between this case
+				// and the timer case below.
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(m.wait)
+			}
+		case <-timerC(timer):
+			timer = nil
+			if err := m.renderAll(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// timerC returns t.C, or a nil channel (which blocks forever) when t is
+// nil, so the select above only fires once a debounce window is armed.
+func timerC(t *time.Timer) <-chan time.Time {
+	if t == nil {
+		return nil
+	}
+	return t.C
+}
+
+func (m *Manager) renderAll() error {
+	for _, r := range m.renderers {
+		if _, err := r.Render(nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
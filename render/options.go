@@ -0,0 +1,92 @@
+package render
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// Options configure a Renderer.
+type Options struct {
+	// Dest is the file the rendered template is written to.
+	Dest string
+	// FileMode is the mode used when writing Dest.
+	FileMode os.FileMode
+	// Owner, if non-nil, chowns Dest to the given uid/gid after writing.
+	Owner *Owner
+	// Command, if set, is run after a changed render.
+	Command []string
+	// Signal, if set, is sent to Process after a changed render.
+	Signal syscall.Signal
+	// Process is the target of Signal.
+	Process *os.Process
+	// DryRun writes the rendered output to stdout instead of Dest and
+	// skips Command/Signal.
+	DryRun bool
+	// Wait records a debounce/quiescence duration on the Renderer. A
+	// Manager does not read it; it debounces by the wait passed to
+	// NewManager.
+	Wait time.Duration
+}
+
+// Option sets a Renderer option.
+type Option func(*Options)
+
+// WithDest sets the destination file the template is rendered to.
+func WithDest(dest string) Option {
+	return func(o *Options) {
+		o.Dest = dest
+	}
+}
+
+// WithFileMode sets the mode used when writing the destination file.
+func WithFileMode(mode os.FileMode) Option {
+	return func(o *Options) {
+		o.FileMode = mode
+	}
+}
+
+// Owner identifies the uid/gid a rendered file is chowned to.
+type Owner struct {
+	UID int
+	GID int
+}
+
+// WithOwner chowns the destination file to uid/gid after each render
+// that changes it.
+func WithOwner(uid, gid int) Option {
+	return func(o *Options) {
+		o.Owner = &Owner{UID: uid, GID: gid}
+	}
+}
+
+// WithCommand sets a command to run after a changed render.
+func WithCommand(cmd ...string) Option {
+	return func(o *Options) {
+		o.Command = cmd
+	}
+}
+
+// WithSignal sets a signal to send to process after a changed render.
+func WithSignal(process *os.Process, sig syscall.Signal) Option {
+	return func(o *Options) {
+		o.Process = process
+		o.Signal = sig
+	}
+}
+
+// WithDryRun writes rendered output to stdout instead of the destination
+// and skips running any command or sending any signal.
+func WithDryRun(b bool) Option {
+	return func(o *Options) {
+		o.DryRun = b
+	}
+}
+
+// WithWait sets Options.Wait. A Manager does not read it; its debounce
+// window is the wait passed to NewManager.
+func WithWait(d time.Duration) Option {
+	return func(o *Options) {
+		o.Wait = d
+	}
+}
@@ -0,0 +1,143 @@
+// Package render implements a consul-template style templating layer on
+// top of a config.Config. A Renderer evaluates a text/template file whose
+// actions reference config paths (key, keyOrDefault, tree) and writes the
+// result to a destination, optionally running a command or sending a
+// signal when the rendered output changes.
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"text/template"
+
+	config "github.com/DoraALin/go-config"
+)
+
+// Renderer renders a single template against a Config.
+type Renderer struct {
+	conf config.Config
+	opts Options
+	tpl  *template.Template
+
+	// last is the checksum-free rendered output, used to detect changes
+	last []byte
+}
+
+// New creates a Renderer for the template at src. The template is parsed
+// immediately so syntax errors surface at construction time rather than on
+// the first render.
+func New(conf config.Config, src string, opts ...Option) (*Renderer, error) {
+	options := Options{
+		FileMode: 0644,
+	}
+
+	for _, o := range opts {
+		o(&options)
+	}
+
+	b, err := ioutil.ReadFile(src)
+	if err != nil {
+		return nil, fmt.Errorf("error reading template %s: %v", src, err)
+	}
+
+	tpl, err := template.New(filepath.Base(src)).Funcs(funcMap(conf)).Parse(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing template %s: %v", src, err)
+	}
+
+	return &Renderer{
+		conf: conf,
+		opts: options,
+		tpl:  tpl,
+	}, nil
+}
+
+// Render executes the template and writes it to the configured
+// destination. If dry run is enabled the output is written to w instead
+// of the destination and no command/signal is executed. It returns true
+// if the rendered output changed since the previous call; in dry-run mode
+// it always returns false.
+func (r *Renderer) Render(w io.Writer) (bool, error) {
+	var buf bytes.Buffer
+	if err := r.tpl.Execute(&buf, nil); err != nil {
+		return false, fmt.Errorf("error executing template: %v", err)
+	}
+	out := buf.Bytes()
+
+	if r.opts.DryRun {
+		_, err := w.Write(out)
+		return false, err
+	}
+
+	if bytes.Equal(out, r.last) {
+		return false, nil
+	}
+
+	if err := atomicWrite(r.opts.Dest, out, r.opts.FileMode, r.opts.Owner); err != nil {
+		return false, fmt.Errorf("error writing %s: %v", r.opts.Dest, err)
+	}
+	r.last = out
+
+	if err := r.notify(); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// notify runs the configured command and/or sends the configured signal
+// after a successful render.
+func (r *Renderer) notify() error {
+	if len(r.opts.Command) > 0 {
+		cmd := exec.Command(r.opts.Command[0], r.opts.Command[1:]...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("error running command %v: %v", r.opts.Command, err)
+		}
+	}
+
+	if r.opts.Signal != syscall.Signal(0) && r.opts.Process != nil {
+		if err := r.opts.Process.Signal(r.opts.Signal); err != nil {
+			return fmt.Errorf("error signalling process: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// atomicWrite writes data to a temp file in the destination's directory
+// and renames it into place, so readers never observe a partial write.
+// The temp file is chowned to owner, when set, before the rename so the
+// final file never exists with the wrong owner even momentarily.
+func atomicWrite(dest string, data []byte, mode os.FileMode, owner *Owner) error {
+	dir := filepath.Dir(dest)
+	tmp, err := ioutil.TempFile(dir, ".render-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	if owner != nil {
+		if err := os.Chown(tmp.Name(), owner.UID, owner.GID); err != nil {
+			return err
+		}
+	}
+	return os.Rename(tmp.Name(), dest)
+}
@@ -1,21 +1,25 @@
 package consul
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/json"
 	"fmt"
 	"net"
+	"strings"
 
 	"github.com/hashicorp/consul/api"
 	"github.com/DoraALin/go-config/source"
+	kvutil "github.com/DoraALin/go-config/source/internal/kv"
 )
 
 // Currently a single consul reader
 type consul struct {
-	prefix string
-	addr   string
-	opts   source.Options
-	client *api.Client
+	prefix      string
+	stripPrefix bool
+	addr        string
+	opts        source.Options
+	client      *api.Client
 }
 
 var (
@@ -35,7 +39,19 @@ func (c *consul) Read() (*source.ChangeSet, error) {
 	data := make(map[string]interface{})
 
 	for _, v := range kv {
-		data[v.Key] = v.Value
+		// consul returns keys without a leading "/" even though prefixes
+		// such as DefaultPrefix carry one, so both are compared without it.
+		key := strings.TrimPrefix(v.Key, "/")
+		if c.stripPrefix {
+			key = strings.TrimPrefix(key, strings.TrimPrefix(c.prefix, "/"))
+		}
+		// trim the "/" left after a stripped prefix so SetNested doesn't
+		// nest everything under a bogus "" top key.
+		key = strings.TrimPrefix(key, "/")
+		if len(key) == 0 {
+			continue
+		}
+		kvutil.SetNested(data, strings.Split(key, "/"), kvutil.DecodeValue(string(v.Value)))
 	}
 
 	b, err := json.Marshal(data)
@@ -58,8 +74,8 @@ func (c *consul) String() string {
 	return "consul"
 }
 
-func (c *consul) Watch() (source.Watcher, error) {
-	w, err := newWatcher(c.prefix, c.addr, c.String())
+func (c *consul) Watch(ctx context.Context) (source.Watcher, error) {
+	w, err := newWatcher(ctx, c.prefix, c.addr, c.String())
 	if err != nil {
 		return nil, err
 	}
@@ -102,10 +118,19 @@ func NewSource(opts ...source.Option) source.Source {
 		}
 	}
 
+	var stripPrefix bool
+	if options.Context != nil {
+		s, ok := options.Context.Value(stripPrefixKey{}).(bool)
+		if ok {
+			stripPrefix = s
+		}
+	}
+
 	return &consul{
-		prefix: prefix,
-		addr:   config.Address,
-		opts:   options,
-		client: client,
+		prefix:      prefix,
+		stripPrefix: stripPrefix,
+		addr:        config.Address,
+		opts:        options,
+		client:      client,
 	}
 }
@@ -0,0 +1,45 @@
+package consul
+
+import (
+	"context"
+
+	"github.com/DoraALin/go-config/source"
+)
+
+type addressKey struct{}
+type prefixKey struct{}
+type stripPrefixKey struct{}
+
+// WithAddress sets the consul agent address, e.g. "10.0.0.1:8500".
+func WithAddress(a string) source.Option {
+	return func(o *source.Options) {
+		if o.Context == nil {
+			o.Context = context.Background()
+		}
+		o.Context = context.WithValue(o.Context, addressKey{}, a)
+	}
+}
+
+// WithPrefix sets the KV prefix the source reads and watches, in place of
+// DefaultPrefix.
+func WithPrefix(p string) source.Option {
+	return func(o *source.Options) {
+		if o.Context == nil {
+			o.Context = context.Background()
+		}
+		o.Context = context.WithValue(o.Context, prefixKey{}, p)
+	}
+}
+
+// StripPrefix, when true, drops the configured prefix from each KV key
+// before building the config tree, so a key stored under
+// "micro/config/database" becomes accessible as conf.Get("database")
+// rather than conf.Get("micro", "config", "database").
+func StripPrefix(strip bool) source.Option {
+	return func(o *source.Options) {
+		if o.Context == nil {
+			o.Context = context.Background()
+		}
+		o.Context = context.WithValue(o.Context, stripPrefixKey{}, strip)
+	}
+}
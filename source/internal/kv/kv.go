@@ -0,0 +1,33 @@
+// Package kv holds helpers shared by source implementations (consul,
+// redis) that read flat key/value data and assemble it into the nested
+// config tree a ChangeSet's Data expects.
+package kv
+
+import "encoding/json"
+
+// SetNested writes value into m at the path described by keys, creating
+// intermediate maps as needed, so a key like "database/address" produces
+// {"database": {"address": value}} instead of a flat single-level map.
+func SetNested(m map[string]interface{}, keys []string, value interface{}) {
+	for _, k := range keys[:len(keys)-1] {
+		next, ok := m[k].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			m[k] = next
+		}
+		m = next
+	}
+	m[keys[len(keys)-1]] = value
+}
+
+// DecodeValue decodes a raw value as JSON where possible, falling back
+// to the raw string so plain values (e.g. "10.0.0.1") round trip instead
+// of being base64-encoded as []byte would be. A value that is valid JSON,
+// such as "6379", decodes to its JSON type (here a number).
+func DecodeValue(raw string) interface{} {
+	var v interface{}
+	if err := json.Unmarshal([]byte(raw), &v); err == nil {
+		return v
+	}
+	return raw
+}
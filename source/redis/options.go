@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/DoraALin/go-config/source"
+)
+
+type addressKey struct{}
+type dbKey struct{}
+type passwordKey struct{}
+type keyKey struct{}
+type prefixKey struct{}
+type tlsConfigKey struct{}
+type pollIntervalKey struct{}
+
+// WithAddress sets the redis server address, e.g. "127.0.0.1:6379".
+func WithAddress(a string) source.Option {
+	return setContext(addressKey{}, a)
+}
+
+// WithDB selects the redis logical database (SELECT n).
+func WithDB(db int) source.Option {
+	return setContext(dbKey{}, db)
+}
+
+// WithPassword sets the redis AUTH password.
+func WithPassword(p string) source.Option {
+	return setContext(passwordKey{}, p)
+}
+
+// WithKey reads a single string or hash key instead of a prefix scan.
+func WithKey(key string) source.Option {
+	return setContext(keyKey{}, key)
+}
+
+// WithPrefix reads all keys under prefix via SCAN, building a nested
+// config tree the same way the consul source does for its KV prefix.
+func WithPrefix(prefix string) source.Option {
+	return setContext(prefixKey{}, prefix)
+}
+
+// WithTLS enables TLS using the given config when connecting to redis.
+func WithTLS(c *tls.Config) source.Option {
+	return setContext(tlsConfigKey{}, c)
+}
+
+// WithPollInterval enables a fallback polling mode, re-reading every
+// interval instead of relying on keyspace notifications. Use this when
+// the redis server does not have notify-keyspace-events enabled.
+func WithPollInterval(interval time.Duration) source.Option {
+	return setContext(pollIntervalKey{}, interval)
+}
+
+func setContext(k, v interface{}) source.Option {
+	return func(o *source.Options) {
+		if o.Context == nil {
+			o.Context = context.Background()
+		}
+		o.Context = context.WithValue(o.Context, k, v)
+	}
+}
@@ -0,0 +1,198 @@
+// Package redis provides a source.Source and source.Watcher backed by
+// Redis, using keyspace notifications to watch for changes.
+//
+// Watching keyspace events requires the redis server to be started (or
+// reconfigured at runtime via CONFIG SET) with:
+//
+//	notify-keyspace-events KEA
+//
+// If notifications are not enabled, pass WithPollInterval to fall back to
+// re-reading the key(s) on a timer instead.
+package redis
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/DoraALin/go-config/source"
+	"github.com/DoraALin/go-config/source/internal/kv"
+)
+
+type redisSource struct {
+	client *redis.Client
+	opts   source.Options
+
+	db     int
+	key    string
+	prefix string
+	poll   time.Duration
+}
+
+// NewSource returns a source.Source that reads configuration out of
+// Redis, either a single key (string or hash, via WithKey) or a prefix of
+// keys scanned and assembled into a nested tree (via WithPrefix).
+func NewSource(opts ...source.Option) source.Source {
+	var options source.Options
+	for _, o := range opts {
+		o(&options)
+	}
+
+	addr := "127.0.0.1:6379"
+	var password string
+	var db int
+	var tlsConfig *tls.Config
+	var key, prefix string
+	var poll time.Duration
+
+	if options.Context != nil {
+		if a, ok := options.Context.Value(addressKey{}).(string); ok {
+			addr = a
+		}
+		if p, ok := options.Context.Value(passwordKey{}).(string); ok {
+			password = p
+		}
+		if d, ok := options.Context.Value(dbKey{}).(int); ok {
+			db = d
+		}
+		if c, ok := options.Context.Value(tlsConfigKey{}).(*tls.Config); ok {
+			tlsConfig = c
+		}
+		if k, ok := options.Context.Value(keyKey{}).(string); ok {
+			key = k
+		}
+		if p, ok := options.Context.Value(prefixKey{}).(string); ok {
+			prefix = p
+		}
+		if d, ok := options.Context.Value(pollIntervalKey{}).(time.Duration); ok {
+			poll = d
+		}
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:      addr,
+		Password:  password,
+		DB:        db,
+		TLSConfig: tlsConfig,
+	})
+
+	return &redisSource{
+		client: client,
+		opts:   options,
+		db:     db,
+		key:    key,
+		prefix: prefix,
+		poll:   poll,
+	}
+}
+
+func (r *redisSource) String() string {
+	return "redis"
+}
+
+func (r *redisSource) Read() (*source.ChangeSet, error) {
+	ctx := context.Background()
+
+	data := make(map[string]interface{})
+
+	switch {
+	case len(r.key) > 0:
+		v, err := r.readKey(ctx, r.key)
+		if err != nil {
+			return nil, err
+		}
+		kv.SetNested(data, splitKey(r.key), v)
+	case len(r.prefix) > 0:
+		keys, err := r.scan(ctx, r.prefix+"*")
+		if err != nil {
+			return nil, err
+		}
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("source not found: %s", r.prefix)
+		}
+		for _, k := range keys {
+			v, err := r.readKey(ctx, k)
+			if err != nil {
+				continue
+			}
+			rel := strings.TrimPrefix(strings.TrimPrefix(k, r.prefix), "/")
+			kv.SetNested(data, splitKey(rel), v)
+		}
+	default:
+		return nil, fmt.Errorf("no key or prefix configured")
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("error reading source: %v", err)
+	}
+
+	h := md5.New()
+	h.Write(b)
+
+	return &source.ChangeSet{
+		Source:   r.String(),
+		Data:     b,
+		Checksum: fmt.Sprintf("%x", h.Sum(nil)),
+	}, nil
+}
+
+// readKey reads key as a string, falling back to a hash if it is one,
+// decoding the leaf value as JSON where possible. It returns an error,
+// same as consul's missing-prefix case, if key doesn't exist as either:
+// HGetAll returns an empty map with no error for a missing key, which
+// would otherwise let a typo'd key silently read back as {}.
+func (r *redisSource) readKey(ctx context.Context, key string) (interface{}, error) {
+	s, err := r.client.Get(ctx, key).Result()
+	if err == nil {
+		return kv.DecodeValue(s), nil
+	}
+	// GET on a hash fails with a WRONGTYPE error rather than redis.Nil
+	if err != redis.Nil && !strings.HasPrefix(err.Error(), "WRONGTYPE") {
+		return nil, err
+	}
+
+	h, err := r.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(h) == 0 {
+		return nil, fmt.Errorf("source not found: %s", key)
+	}
+	out := make(map[string]interface{}, len(h))
+	for f, v := range h {
+		out[f] = kv.DecodeValue(v)
+	}
+	return out, nil
+}
+
+func (r *redisSource) scan(ctx context.Context, match string) ([]string, error) {
+	var keys []string
+	var cursor uint64
+	for {
+		batch, next, err := r.client.Scan(ctx, cursor, match, 100).Result()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, batch...)
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+	return keys, nil
+}
+
+func (r *redisSource) Watch(ctx context.Context) (source.Watcher, error) {
+	return newWatcher(ctx, r)
+}
+
+func splitKey(key string) []string {
+	return strings.Split(key, "/")
+}
@@ -0,0 +1,149 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/DoraALin/go-config/source"
+)
+
+type watcher struct {
+	src    *redisSource
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	updates chan *source.ChangeSet
+	errs    chan error
+}
+
+// newWatcher subscribes to keyspace notifications for the source's key or
+// prefix, or falls back to polling on an interval when WithPollInterval
+// was set. The watch is bound to parent and stops when it is cancelled.
+func newWatcher(parent context.Context, src *redisSource) (source.Watcher, error) {
+	ctx, cancel := context.WithCancel(parent)
+
+	w := &watcher{
+		src:     src,
+		ctx:     ctx,
+		cancel:  cancel,
+		updates: make(chan *source.ChangeSet, 1),
+		errs:    make(chan error, 1),
+	}
+
+	if src.poll > 0 {
+		go w.pollLoop()
+		return w, nil
+	}
+
+	// a single key is watched with an exact SUBSCRIBE so writes to
+	// sibling keys sharing the same prefix (e.g. "foobar" vs "foo")
+	// don't also wake this watcher; a prefix scan needs the "*"
+	// wildcard to catch every key under it.
+	var pubsub *redis.PubSub
+	if len(src.key) > 0 {
+		channel := fmt.Sprintf("__keyspace@%d__:%s", src.db, src.key)
+		pubsub = src.client.Subscribe(ctx, channel)
+	} else {
+		pattern := fmt.Sprintf("__keyspace@%d__:%s*", src.db, src.prefix)
+		pubsub = src.client.PSubscribe(ctx, pattern)
+	}
+	go w.subscribeLoop(pubsub)
+	return w, nil
+}
+
+func (w *watcher) subscribeLoop(pubsub *redis.PubSub) {
+	defer pubsub.Close()
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+			cs, err := w.src.Read()
+			if err != nil {
+				w.sendErr(err)
+				continue
+			}
+			w.sendUpdate(cs)
+		}
+	}
+}
+
+func (w *watcher) pollLoop() {
+	ticker := time.NewTicker(w.src.poll)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			cs, err := w.src.Read()
+			if err != nil {
+				w.sendErr(err)
+				continue
+			}
+			w.sendUpdate(cs)
+		}
+	}
+}
+
+// sendUpdate delivers cs on w.updates without blocking. If a previous
+// update is still pending (Next hasn't drained it yet), that stale value
+// is dropped in favor of cs, so a slow caller sees the latest change
+// once it catches up instead of stalling this goroutine forever.
+func (w *watcher) sendUpdate(cs *source.ChangeSet) {
+	select {
+	case w.updates <- cs:
+		return
+	default:
+	}
+	select {
+	case <-w.updates:
+	default:
+	}
+	select {
+	case w.updates <- cs:
+	default:
+	}
+}
+
+// sendErr is sendUpdate's counterpart for w.errs.
+func (w *watcher) sendErr(err error) {
+	select {
+	case w.errs <- err:
+		return
+	default:
+	}
+	select {
+	case <-w.errs:
+	default:
+	}
+	select {
+	case w.errs <- err:
+	default:
+	}
+}
+
+func (w *watcher) Next() (*source.ChangeSet, error) {
+	select {
+	case <-w.ctx.Done():
+		return nil, fmt.Errorf("watcher stopped")
+	case err := <-w.errs:
+		return nil, err
+	case cs := <-w.updates:
+		return cs, nil
+	}
+}
+
+func (w *watcher) Stop() error {
+	w.cancel()
+	return nil
+}
@@ -0,0 +1,171 @@
+//go:build integration
+
+// This file exercises config against a real Vault server; see
+// TestWatchVaultKeyRotation for the environment variables it needs and
+// how to run it.
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+
+	"github.com/DoraALin/go-config/crypto/vault"
+	"github.com/DoraALin/go-config/source"
+)
+
+// fakeSource is a minimal source.Source whose Watch never talks to a
+// real backend: the test drives it directly via push, so it can force
+// exactly the sequence of changes the rotation scenario needs.
+type fakeSource struct {
+	mu      sync.Mutex
+	current *source.ChangeSet
+	ch      chan *source.ChangeSet
+}
+
+func newFakeSource(initial *source.ChangeSet) *fakeSource {
+	return &fakeSource{current: initial, ch: make(chan *source.ChangeSet, 1)}
+}
+
+func (s *fakeSource) String() string { return "fake" }
+
+func (s *fakeSource) Read() (*source.ChangeSet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current, nil
+}
+
+func (s *fakeSource) Watch(ctx context.Context) (source.Watcher, error) {
+	return &fakeWatcher{ctx: ctx, ch: s.ch}, nil
+}
+
+// push makes cs the source's current value and wakes any active watcher.
+func (s *fakeSource) push(cs *source.ChangeSet) {
+	s.mu.Lock()
+	s.current = cs
+	s.mu.Unlock()
+
+	select {
+	case <-s.ch:
+	default:
+	}
+	s.ch <- cs
+}
+
+type fakeWatcher struct {
+	ctx context.Context
+	ch  chan *source.ChangeSet
+}
+
+func (w *fakeWatcher) Next() (*source.ChangeSet, error) {
+	select {
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	case cs := <-w.ch:
+		return cs, nil
+	}
+}
+
+func (w *fakeWatcher) Stop() error { return nil }
+
+// TestWatchVaultKeyRotation proves a config.Watch survives a transit key
+// rotation: a watcher started against a value encrypted under key
+// version 1 keeps working, without restarting the process, once the key
+// is rotated and the value is re-encrypted under version 2.
+//
+// It needs a reachable Vault server with a token allowed to create,
+// rotate and delete transit keys, e.g. a local dev server:
+//
+//	vault server -dev -dev-root-token-id=root
+//	VAULT_ADDR=http://127.0.0.1:8200 VAULT_TOKEN=root \
+//		go test -tags integration -run TestWatchVaultKeyRotation ./...
+func TestWatchVaultKeyRotation(t *testing.T) {
+	addr := os.Getenv("VAULT_ADDR")
+	token := os.Getenv("VAULT_TOKEN")
+	if addr == "" || token == "" {
+		t.Skip("VAULT_ADDR and VAULT_TOKEN must be set to run against a Vault server")
+	}
+
+	client, err := vaultapi.NewClient(&vaultapi.Config{Address: addr})
+	if err != nil {
+		t.Fatalf("creating vault client: %v", err)
+	}
+	client.SetToken(token)
+
+	// the dev server this is usually run against doesn't mount transit
+	// by default; ignore the error if it's already mounted.
+	_ = client.Sys().Mount("transit", &vaultapi.MountInput{Type: "transit"})
+
+	keyName := fmt.Sprintf("go-config-it-%d", time.Now().UnixNano())
+	if _, err := client.Logical().Write("transit/keys/"+keyName, nil); err != nil {
+		t.Fatalf("creating transit key %q: %v", keyName, err)
+	}
+	defer func() {
+		client.Logical().Write("transit/keys/"+keyName+"/config", map[string]interface{}{
+			"deletion_allowed": true,
+		})
+		client.Logical().Delete("transit/keys/" + keyName)
+	}()
+
+	encrypt := func(plaintext string) string {
+		secret, err := client.Logical().Write("transit/encrypt/"+keyName, map[string]interface{}{
+			"plaintext": base64.StdEncoding.EncodeToString([]byte(plaintext)),
+		})
+		if err != nil {
+			t.Fatalf("encrypting %q: %v", plaintext, err)
+		}
+		return fmt.Sprintf("%s:%s", keyName, secret.Data["ciphertext"].(string))
+	}
+
+	changeSet := func(ciphertext string) *source.ChangeSet {
+		return &source.ChangeSet{
+			Source:    "fake",
+			Data:      []byte(fmt.Sprintf(`{"password":"enc:%s"}`, ciphertext)),
+			Timestamp: time.Now(),
+			Checksum:  ciphertext,
+		}
+	}
+
+	src := newFakeSource(changeSet(encrypt("first-secret")))
+
+	d, err := vault.NewDecrypter(vault.WithAddress(addr), vault.WithToken(token))
+	if err != nil {
+		t.Fatalf("creating vault decrypter: %v", err)
+	}
+
+	c := NewConfig(WithSource(src), WithDecrypter(d))
+	defer c.Close()
+
+	if got := c.Get("password").String(""); got != "first-secret" {
+		t.Fatalf("Get(password) = %q, want %q", got, "first-secret")
+	}
+
+	w, err := c.Watch("password")
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	defer w.Stop()
+
+	if _, err := client.Logical().Write("transit/keys/"+keyName+"/rotate", nil); err != nil {
+		t.Fatalf("rotating key %q: %v", keyName, err)
+	}
+
+	// re-encrypt under the now-current (rotated) key version and push it
+	// as a change; the watcher should decrypt it against the live key
+	// without ever being told a rotation happened.
+	src.push(changeSet(encrypt("second-secret")))
+
+	v, err := w.Next()
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if got := v.String(""); got != "second-secret" {
+		t.Fatalf("Next() = %q, want %q", got, "second-secret")
+	}
+}